@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+// Command migrate applies or rolls back the DAL's embedded schema
+// migrations against the configured database backend.
+//
+// This ships as a standalone binary rather than a `--migrate` flag on the
+// rpcservice server: this tree has no server bootstrap/main entry point to
+// wire that flag into (rpcservice only contains the dal and service
+// packages), so migrations.Up/Down are exposed here instead. Once a server
+// entry point exists, its bootstrap should call migrations.Up behind its own
+// `--migrate` flag using the same db.Config/migrations.Up(gormDB, driver)
+// calls this command makes, and this binary can be kept as the ops-facing
+// CLI for manual up/down.
+//
+// Usage:
+//
+//	migrate --driver mysql --host 127.0.0.1 --port 3306 --user root --password secret --dbname stream_to_river
+//	migrate --down --driver postgres --host 127.0.0.1 --port 5432 --user postgres --password secret --dbname stream_to_river --sslmode disable
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/db"
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/migrations"
+)
+
+func main() {
+	down := flag.Bool("down", false, "roll back the most recently applied migration instead of applying pending ones")
+	driver := flag.String("driver", "mysql", "database driver: mysql, postgres or sqlite")
+	host := flag.String("host", "127.0.0.1", "database host")
+	port := flag.Int("port", 3306, "database port")
+	user := flag.String("user", "root", "database user")
+	password := flag.String("password", "", "database password")
+	dbName := flag.String("dbname", "", "database name (or sqlite file path)")
+	sslMode := flag.String("sslmode", "disable", "postgres sslmode")
+	flag.Parse()
+
+	cfg := &db.Config{
+		Driver:   db.Driver(*driver),
+		Host:     *host,
+		Port:     *port,
+		User:     *user,
+		Password: *password,
+		DBName:   *dbName,
+		SSLMode:  *sslMode,
+	}
+
+	if err := db.Init(cfg); err != nil {
+		log.Fatalf("migrate: failed to connect: %v", err)
+	}
+
+	var err error
+	if *down {
+		err = migrations.Down(db.GetDB(), cfg.Driver)
+	} else {
+		err = migrations.Up(db.GetDB(), cfg.Driver)
+	}
+	if err != nil {
+		log.Fatalf("migrate: %v", err)
+	}
+}
@@ -4,12 +4,30 @@
 package dao
 
 import (
+	"context"
+
 	"github.com/cloudwego/kitex/pkg/klog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/db"
 	"github.com/Trae-AI/stream-to-river/rpcservice/dal/model"
-	"github.com/Trae-AI/stream-to-river/rpcservice/dal/mysql"
+	"github.com/Trae-AI/stream-to-river/rpcservice/service/srs"
 )
 
+// reviewRecordUpsertColumns lists the columns that must be refreshed when a
+// words_recite_record row already exists for (user_id, word_id).
+var reviewRecordUpsertColumns = []string{
+	"word_id", "level", "next_review_time", "downgrade_step",
+	"total_correct", "total_wrong", "score", "easiness", "interval", "repetitions",
+}
+
+// Answer is one word's recall quality submitted as part of a review session.
+type Answer struct {
+	WordId  int64
+	Quality int // SM-2 quality score, 0-5.
+}
+
 // AddWordsReciteRecord inserts a new review record into the `words_recite_record` table.
 //
 // Parameters:
@@ -18,7 +36,7 @@ import (
 // Returns:
 //   - error: An error object if an unexpected error occurs during the database operation.
 func AddWordsReciteRecord(record *model.WordsReciteRecord) error {
-	ret := mysql.GetDB().Table(model.WordsReciteRecordTableName).Create(record)
+	ret := db.GetDB().Table(model.WordsReciteRecordTableName).Create(record)
 	if ret.Error != nil {
 		return ret.Error
 	}
@@ -38,7 +56,7 @@ func AddWordsReciteRecord(record *model.WordsReciteRecord) error {
 func GetWordsReciteRecord(userId int64, wordId int64) (*model.WordsReciteRecord, error) {
 	var record model.WordsReciteRecord
 
-	ret := mysql.GetDB().Table(model.WordsReciteRecordTableName).
+	ret := db.GetDB().Table(model.WordsReciteRecordTableName).
 		Where("user_id = ? AND word_id = ?", userId, wordId).
 		First(&record)
 
@@ -63,7 +81,7 @@ func GetWordsReciteRecord(userId int64, wordId int64) (*model.WordsReciteRecord,
 func GetWordsReciteRecordsByUserAndWordIds(userId int64, wordIds []int64) (map[int64]*model.WordsReciteRecord, error) {
 	var records []*model.WordsReciteRecord
 
-	ret := mysql.GetDB().Table(model.WordsReciteRecordTableName).
+	ret := db.GetDB().Table(model.WordsReciteRecordTableName).
 		Where("user_id = ? AND word_id IN ?", userId, wordIds).
 		Find(&records)
 
@@ -82,7 +100,8 @@ func GetWordsReciteRecordsByUserAndWordIds(userId int64, wordIds []int64) (map[i
 }
 
 // UpdateWordsReciteRecord updates an existing review record in the `words_recite_record` table.
-// It updates specific fields (`Level`, `NextReviewTime`, `TotalWrong`, `TotalCorrect`, `Score`) of the record.
+// It updates specific fields (`Level`, `NextReviewTime`, `TotalWrong`, `TotalCorrect`, `Score`,
+// `Easiness`, `Interval`, `Repetitions`) of the record.
 //
 // Parameters:
 //   - record: A pointer to the `model.WordsReciteRecord` struct that represents the updated review record.
@@ -90,9 +109,9 @@ func GetWordsReciteRecordsByUserAndWordIds(userId int64, wordIds []int64) (map[i
 // Returns:
 //   - error: An error object if an unexpected error occurs during the database operation.
 func UpdateWordsReciteRecord(record *model.WordsReciteRecord) error {
-	ret := mysql.GetDB().Table(model.WordsReciteRecordTableName).
+	ret := db.GetDB().Table(model.WordsReciteRecordTableName).
 		Where("user_id = ? AND word_id = ?", record.UserId, record.WordId).
-		Select("Level", "NextReviewTime", "TotalWrong", "TotalCorrect", "Score").
+		Select("Level", "NextReviewTime", "TotalWrong", "TotalCorrect", "Score", "Easiness", "Interval", "Repetitions").
 		Updates(record)
 
 	if ret.Error != nil {
@@ -116,7 +135,7 @@ func UpdateWordsReciteRecord(record *model.WordsReciteRecord) error {
 func GetReviewRecords(userId int64, currentTime int64) ([]*model.WordsReciteRecord, error) {
 	var records []*model.WordsReciteRecord
 
-	ret := mysql.GetDB().Table(model.WordsReciteRecordTableName).
+	ret := db.GetDB().Table(model.WordsReciteRecordTableName).
 		Where("user_id = ? AND next_review_time <= ?", userId, currentTime).
 		Find(&records)
 
@@ -139,7 +158,7 @@ func GetReviewRecords(userId int64, currentTime int64) ([]*model.WordsReciteReco
 //   - error: An error object if an unexpected error occurs during the database operation.
 func GetCompletedWordsCountFromRecord(userId int64) (int32, error) {
 	var count int64
-	ret := mysql.GetDB().Table(model.WordsReciteRecordTableName).
+	ret := db.GetDB().Table(model.WordsReciteRecordTableName).
 		Where("user_id = ? AND level >= ?", userId, 8).
 		Count(&count)
 
@@ -159,7 +178,7 @@ func GetCompletedWordsCountFromRecord(userId int64) (int32, error) {
 // Returns:
 //   - error: An error object if an unexpected error occurs during the database operation.
 func DelWordsReciteRecordByUserID(userId int64) error {
-	ret := mysql.GetDB().Table(model.WordsReciteRecordTableName).
+	ret := db.GetDB().Table(model.WordsReciteRecordTableName).
 		Where("user_id = ?", userId).
 		Delete(&model.WordsReciteRecord{})
 
@@ -170,3 +189,115 @@ func DelWordsReciteRecordByUserID(userId int64) error {
 	klog.Infof("Deleted words_recite_record for user_id=%v", userId)
 	return nil
 }
+
+// UpsertWordsReciteRecords inserts records that don't exist yet and updates
+// the scheduling columns of the ones that do, in a single round trip. It
+// issues `INSERT ... ON DUPLICATE KEY UPDATE` on MySQL and
+// `ON CONFLICT (user_id, word_id) DO UPDATE` on Postgres.
+//
+// Parameters:
+//   - ctx: The context for the database operation.
+//   - records: The `model.WordsReciteRecord` rows to upsert.
+//
+// Returns:
+//   - error: An error object if an unexpected error occurs during the database operation.
+func UpsertWordsReciteRecords(ctx context.Context, records []*model.WordsReciteRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ret := db.GetDB().WithContext(ctx).Table(model.WordsReciteRecordTableName).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "word_id"}},
+			DoUpdates: clause.AssignmentColumns(reviewRecordUpsertColumns),
+		}).
+		// Omit id: records that already exist carry their real, non-zero id,
+		// and including it in the INSERT would collide with the primary key
+		// instead of the (user_id, word_id) conflict target on Postgres/SQLite.
+		Omit("id").
+		Create(records)
+
+	if ret.Error != nil {
+		return ret.Error
+	}
+
+	klog.Infof("Upserted %d words_recite_records", len(records))
+	return nil
+}
+
+// SubmitReviewBatch applies a whole review session for userId in a single
+// transaction: it locks the existing rows for the answered words, recomputes
+// each one's SM-2 schedule from its answer, and upserts the results. This
+// replaces the N+1 pattern of GetReviewRecords followed by a per-word
+// UpdateWordsReciteRecord call.
+//
+// Parameters:
+//   - ctx: The context for the database operation.
+//   - userId: The unique identifier of the user submitting the review.
+//   - answers: The recall quality submitted for each reviewed word.
+//
+// Returns:
+//   - error: An error object if an unexpected error occurs during the database operation.
+func SubmitReviewBatch(ctx context.Context, userId int64, answers []Answer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	wordIds := make([]int64, 0, len(answers))
+	for _, answer := range answers {
+		wordIds = append(wordIds, answer.WordId)
+	}
+
+	// SQLite has no FOR UPDATE syntax and gorm's sqlite dialect doesn't
+	// strip clause.Locking, so it would reach the database as a literal
+	// syntax error. SQLite's single-writer model makes row locking
+	// unnecessary there anyway, so only apply it on drivers that support it.
+	needsRowLock := db.CurrentDriver() != db.DriverSQLite
+
+	err := db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Table(model.WordsReciteRecordTableName)
+		if needsRowLock {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+
+		var records []*model.WordsReciteRecord
+		if err := query.
+			Where("user_id = ? AND word_id IN ?", userId, wordIds).
+			Find(&records).Error; err != nil {
+			return err
+		}
+
+		recordByWordId := make(map[int64]*model.WordsReciteRecord, len(records))
+		for _, record := range records {
+			recordByWordId[int64(record.WordId)] = record
+		}
+
+		toUpsert := make([]*model.WordsReciteRecord, 0, len(answers))
+		for _, answer := range answers {
+			record, ok := recordByWordId[answer.WordId]
+			if !ok {
+				record = &model.WordsReciteRecord{
+					UserId:   userId,
+					WordId:   int(answer.WordId),
+					Easiness: srs.DefaultEasiness,
+				}
+			}
+			toUpsert = append(toUpsert, srs.Schedule(record, answer.Quality))
+		}
+
+		return tx.Table(model.WordsReciteRecordTableName).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "word_id"}},
+				DoUpdates: clause.AssignmentColumns(reviewRecordUpsertColumns),
+			}).
+			Omit("id").
+			Create(toUpsert).Error
+	})
+
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("Submitted review batch of %d answers for user_id=%v", len(answers), userId)
+	return nil
+}
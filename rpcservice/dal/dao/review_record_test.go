@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/db"
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/migrations"
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/model"
+)
+
+func openMigratedSQLite(t *testing.T) {
+	t.Helper()
+
+	cfg := &db.Config{
+		Driver: db.DriverSQLite,
+		DBName: "file::memory:?cache=shared",
+	}
+	if err := db.Init(cfg); err != nil {
+		t.Fatalf("db.Init() error = %v", err)
+	}
+
+	sqlDB, err := db.GetDB().DB()
+	if err != nil {
+		t.Fatalf("failed to get underlying sql.DB: %v", err)
+	}
+	// Keep every pooled connection on the same shared in-memory database.
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := migrations.Up(db.GetDB(), db.DriverSQLite); err != nil {
+		t.Fatalf("migrations.Up() error = %v", err)
+	}
+}
+
+func TestUpsertWordsReciteRecords_SQLite(t *testing.T) {
+	openMigratedSQLite(t)
+	ctx := context.Background()
+
+	record := &model.WordsReciteRecord{UserId: 1, WordId: 1, Level: 1, Easiness: 2.5}
+	if err := UpsertWordsReciteRecords(ctx, []*model.WordsReciteRecord{record}); err != nil {
+		t.Fatalf("UpsertWordsReciteRecords() insert error = %v", err)
+	}
+
+	record.Level = 2
+	if err := UpsertWordsReciteRecords(ctx, []*model.WordsReciteRecord{record}); err != nil {
+		t.Fatalf("UpsertWordsReciteRecords() update error = %v", err)
+	}
+
+	got, err := GetWordsReciteRecord(1, 1)
+	if err != nil {
+		t.Fatalf("GetWordsReciteRecord() error = %v", err)
+	}
+	if got.Level != 2 {
+		t.Errorf("Level = %d, want 2", got.Level)
+	}
+}
+
+// TestSubmitReviewBatch_SQLite exercises SubmitReviewBatch against SQLite,
+// which has no FOR UPDATE syntax: the row-locking clause must be skipped on
+// this driver or the transaction fails with a syntax error.
+func TestSubmitReviewBatch_SQLite(t *testing.T) {
+	openMigratedSQLite(t)
+	ctx := context.Background()
+
+	if err := AddWordsReciteRecord(&model.WordsReciteRecord{UserId: 2, WordId: 1, Easiness: 2.5}); err != nil {
+		t.Fatalf("AddWordsReciteRecord() error = %v", err)
+	}
+
+	answers := []Answer{
+		{WordId: 1, Quality: 5},
+		{WordId: 2, Quality: 4}, // no existing row; SubmitReviewBatch must create one.
+	}
+	if err := SubmitReviewBatch(ctx, 2, answers); err != nil {
+		t.Fatalf("SubmitReviewBatch() error = %v", err)
+	}
+
+	records, err := GetWordsReciteRecordsByUserAndWordIds(2, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("GetWordsReciteRecordsByUserAndWordIds() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[1].Repetitions != 1 {
+		t.Errorf("word 1 Repetitions = %d, want 1", records[1].Repetitions)
+	}
+	if records[2].Repetitions != 1 {
+		t.Errorf("word 2 Repetitions = %d, want 1", records[2].Repetitions)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+// Package db provides a pluggable database backend for the DAL. It wraps
+// *gorm.DB behind a Store interface so callers (e.g. rpcservice/dal/dao) no
+// longer depend on a specific driver and can be pointed at MySQL, PostgreSQL
+// or SQLite purely via configuration.
+package db
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Driver identifies the SQL dialect to connect with.
+type Driver string
+
+const (
+	DriverMySQL    Driver = "mysql"
+	DriverPostgres Driver = "postgres"
+	DriverSQLite   Driver = "sqlite"
+)
+
+// Config holds the connection parameters for every supported Driver. Fields
+// that don't apply to the selected Driver are ignored.
+type Config struct {
+	Driver   Driver // "mysql", "postgres" or "sqlite"
+	Host     string
+	Port     int
+	User     string
+	Password string
+	DBName   string
+	SSLMode  string // postgres only, e.g. "disable", "require"
+	Charset  string // mysql only, defaults to "utf8mb4"
+}
+
+// Store wraps the underlying *gorm.DB so the DAL can depend on an interface
+// instead of a concrete driver.
+type Store interface {
+	DB() *gorm.DB
+	Close() error
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func (s *gormStore) DB() *gorm.DB {
+	return s.db
+}
+
+func (s *gormStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+var (
+	mu    sync.RWMutex
+	store Store
+)
+
+// Init opens a connection for cfg.Driver and registers it as the package's
+// default Store. It must be called once during server bootstrap before any
+// DAL function runs.
+func Init(cfg *Config) error {
+	dialector, err := dialectorFor(cfg)
+	if err != nil {
+		return err
+	}
+
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return fmt.Errorf("db: failed to open %s connection: %w", cfg.Driver, err)
+	}
+
+	mu.Lock()
+	store = &gormStore{db: gormDB}
+	mu.Unlock()
+
+	klog.Infof("db: connected to %s database=%s", cfg.Driver, cfg.DBName)
+	return nil
+}
+
+// SetStore overrides the package's default Store. It is mainly useful for
+// tests that want to inject an in-memory SQLite store.
+func SetStore(s Store) {
+	mu.Lock()
+	defer mu.Unlock()
+	store = s
+}
+
+// GetDB returns the *gorm.DB handle for the currently configured Store.
+// Init (or SetStore) must have been called beforehand.
+func GetDB() *gorm.DB {
+	mu.RLock()
+	defer mu.RUnlock()
+	if store == nil {
+		panic("db: GetDB called before Init")
+	}
+	return store.DB()
+}
+
+// CurrentDriver returns the Driver backing the currently configured Store,
+// e.g. so callers can skip syntax the dialect doesn't support. It relies on
+// gorm's dialector name, which matches the Driver constants above.
+func CurrentDriver() Driver {
+	return Driver(GetDB().Name())
+}
+
+func dialectorFor(cfg *Config) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case DriverMySQL:
+		charset := cfg.Charset
+		if charset == "" {
+			charset = "utf8mb4"
+		}
+		// multiStatements=true lets migrations.Up execute a migration file
+		// that bundles several semicolon-separated statements in one Exec.
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=%s&parseTime=True&loc=Local&multiStatements=true",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName, charset)
+		return mysql.Open(dsn), nil
+	case DriverPostgres:
+		sslMode := cfg.SSLMode
+		if sslMode == "" {
+			sslMode = "disable"
+		}
+		dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, sslMode)
+		return postgres.Open(dsn), nil
+	case DriverSQLite:
+		return sqlite.Open(cfg.DBName), nil
+	default:
+		return nil, fmt.Errorf("db: unsupported driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+package db
+
+import "testing"
+
+func TestInit_SQLiteRoundTrip(t *testing.T) {
+	cfg := &Config{
+		Driver: DriverSQLite,
+		DBName: "file::memory:?cache=shared",
+	}
+
+	if err := Init(cfg); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if got := CurrentDriver(); got != DriverSQLite {
+		t.Errorf("CurrentDriver() = %q, want %q", got, DriverSQLite)
+	}
+
+	if err := GetDB().Exec("CREATE TABLE db_round_trip (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("failed to exercise the opened connection: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+// Package migrations embeds the SQL schema for the DAL and applies it to
+// whatever backend rpcservice/dal/db is configured with. Each supported
+// driver gets its own directory of SQL, since DDL (autoincrement syntax,
+// reserved words, column types) isn't portable across MySQL/Postgres/SQLite.
+// Migrations are timestamped pairs of files under sql/<driver>/, e.g.:
+//
+//	sql/mysql/00000000000001_create_words_recite_record.up.sql
+//	sql/mysql/00000000000001_create_words_recite_record.down.sql
+//
+// Applied versions are tracked in a schema_migrations table so Up is
+// idempotent and Down only reverts the most recently applied migration.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+	"gorm.io/gorm"
+
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/db"
+)
+
+//go:embed sql/mysql/*.sql sql/postgres/*.sql sql/sqlite/*.sql
+var sqlFS embed.FS
+
+const migrationsTable = "schema_migrations"
+
+// migration is a single versioned schema change.
+type migration struct {
+	Version     string
+	Description string
+	Up          string
+	Down        string
+}
+
+// load reads and pairs up every *.up.sql/*.down.sql file under sql/<driver>/,
+// sorted by version.
+func load(driver db.Driver) ([]migration, error) {
+	dir := "sql/" + string(driver)
+	entries, err := fs.ReadDir(sqlFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("migrations: failed to read embedded sql dir for driver %q: %w", driver, err)
+	}
+
+	byVersion := make(map[string]*migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		isUp := strings.HasSuffix(name, ".up.sql")
+		isDown := strings.HasSuffix(name, ".down.sql")
+		if !isUp && !isDown {
+			continue
+		}
+
+		base := strings.TrimSuffix(strings.TrimSuffix(name, ".up.sql"), ".down.sql")
+		parts := strings.SplitN(base, "_", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("migrations: unexpected file name %q", name)
+		}
+		version, description := parts[0], parts[1]
+
+		content, err := sqlFS.ReadFile(dir + "/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("migrations: failed to read %q: %w", name, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Description: description}
+			byVersion[version] = m
+		}
+		if isUp {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrationsList := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrationsList = append(migrationsList, *m)
+	}
+	sort.Slice(migrationsList, func(i, j int) bool {
+		return migrationsList[i].Version < migrationsList[j].Version
+	})
+
+	return migrationsList, nil
+}
+
+func ensureMigrationsTable(db *gorm.DB) error {
+	return db.Exec(fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			version VARCHAR(14) PRIMARY KEY,
+			description VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`, migrationsTable)).Error
+}
+
+func appliedVersions(db *gorm.DB) (map[string]bool, error) {
+	var versions []string
+	if err := db.Table(migrationsTable).Order("version").Pluck("version", &versions).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+	return applied, nil
+}
+
+// Up applies every migration that hasn't run yet for driver, in version order.
+func Up(gormDB *gorm.DB, driver db.Driver) error {
+	if err := ensureMigrationsTable(gormDB); err != nil {
+		return fmt.Errorf("migrations: failed to ensure %s table: %w", migrationsTable, err)
+	}
+
+	migrationsList, err := load(driver)
+	if err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(gormDB)
+	if err != nil {
+		return fmt.Errorf("migrations: failed to read applied versions: %w", err)
+	}
+
+	for _, m := range migrationsList {
+		if applied[m.Version] {
+			continue
+		}
+
+		err := gormDB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return err
+			}
+			return tx.Table(migrationsTable).Create(map[string]interface{}{
+				"version":     m.Version,
+				"description": m.Description,
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migrations: failed to apply %s_%s: %w", m.Version, m.Description, err)
+		}
+
+		klog.Infof("migrations: applied %s_%s", m.Version, m.Description)
+	}
+
+	return nil
+}
+
+// Down rolls back the most recently applied migration for driver.
+func Down(gormDB *gorm.DB, driver db.Driver) error {
+	if err := ensureMigrationsTable(gormDB); err != nil {
+		return fmt.Errorf("migrations: failed to ensure %s table: %w", migrationsTable, err)
+	}
+
+	var version string
+	err := gormDB.Table(migrationsTable).Order("version desc").Limit(1).Pluck("version", &version).Error
+	if err != nil {
+		return fmt.Errorf("migrations: failed to find last applied version: %w", err)
+	}
+	if version == "" {
+		klog.Infof("migrations: nothing to roll back")
+		return nil
+	}
+
+	migrationsList, err := load(driver)
+	if err != nil {
+		return err
+	}
+
+	var target *migration
+	for i := range migrationsList {
+		if migrationsList[i].Version == version {
+			target = &migrationsList[i]
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("migrations: no migration found for applied version %s", version)
+	}
+
+	err = gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(target.Down).Error; err != nil {
+			return err
+		}
+		return tx.Table(migrationsTable).Where("version = ?", target.Version).Delete(nil).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migrations: failed to roll back %s_%s: %w", target.Version, target.Description, err)
+	}
+
+	klog.Infof("migrations: rolled back %s_%s", target.Version, target.Description)
+	return nil
+}
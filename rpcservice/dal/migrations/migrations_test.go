@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/db"
+)
+
+func openSQLite(t *testing.T) {
+	t.Helper()
+
+	cfg := &db.Config{
+		Driver: db.DriverSQLite,
+		DBName: "file::memory:?cache=shared",
+	}
+	if err := db.Init(cfg); err != nil {
+		t.Fatalf("db.Init() error = %v", err)
+	}
+
+	sqlDB, err := db.GetDB().DB()
+	if err != nil {
+		t.Fatalf("failed to get underlying sql.DB: %v", err)
+	}
+	// Keep every connection on the same shared in-memory database.
+	sqlDB.SetMaxOpenConns(1)
+}
+
+func TestUpDown_SQLiteRoundTrip(t *testing.T) {
+	openSQLite(t)
+	gormDB := db.GetDB()
+
+	if err := Up(gormDB, db.DriverSQLite); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	var versionCount int64
+	if err := gormDB.Table(migrationsTable).Count(&versionCount).Error; err != nil {
+		t.Fatalf("failed to count applied migrations: %v", err)
+	}
+	if versionCount != 2 {
+		t.Fatalf("applied migrations = %d, want 2", versionCount)
+	}
+
+	// The second migration should have added the SRS columns onto the table
+	// the first migration created.
+	insert := `INSERT INTO words_recite_record
+		(word_id, level, next_review_time, downgrade_step, total_correct, total_wrong, score, user_id, easiness, interval, repetitions)
+		VALUES (1, 0, 0, 0, 0, 0, 0, 1, 2.5, 0, 0)`
+	if err := gormDB.Exec(insert).Error; err != nil {
+		t.Fatalf("failed to insert a row against the migrated schema: %v", err)
+	}
+
+	if err := Down(gormDB, db.DriverSQLite); err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+
+	if err := gormDB.Table(migrationsTable).Count(&versionCount).Error; err != nil {
+		t.Fatalf("failed to count applied migrations after Down: %v", err)
+	}
+	if versionCount != 1 {
+		t.Fatalf("applied migrations after Down = %d, want 1", versionCount)
+	}
+
+	// The SRS columns should be gone now that the second migration was rolled back.
+	if err := gormDB.Exec(insert).Error; err == nil {
+		t.Fatalf("expected insert referencing rolled-back columns to fail")
+	}
+}
@@ -8,15 +8,18 @@ const WordsReciteRecordTableName = "words_recite_record"
 
 // WordsReciteRecord represents a record in the `words_recite_record` database table.
 type WordsReciteRecord struct {
-	Id             int64 `gorm:"column:id;primaryKey;autoIncrement"` // Primary key, auto - incremented.
-	WordId         int   `gorm:"column:word_id"`                     // Unique identifier for the word.
-	Level          int   `gorm:"column:level"`                       // Level of the word review.
-	NextReviewTime int64 `gorm:"column:next_review_time"`            // Timestamp for the next review.
-	DowngradeStep  int   `gorm:"column:downgrade_step"`              // Downgrade step for the word review.
-	TotalCorrect   int   `gorm:"column:total_correct"`               // Total number of correct answers.
-	TotalWrong     int   `gorm:"column:total_wrong"`                 // Total number of wrong answers.
-	Score          int   `gorm:"column:score"`                       // Score of the word review.
-	UserId         int64 `gorm:"column:user_id"`                     // Unique identifier for the user.
+	Id             int64   `gorm:"column:id;primaryKey;autoIncrement"` // Primary key, auto - incremented.
+	WordId         int     `gorm:"column:word_id"`                     // Unique identifier for the word.
+	Level          int     `gorm:"column:level"`                       // Level of the word review, derived from Repetitions and capped at 8.
+	NextReviewTime int64   `gorm:"column:next_review_time"`            // Timestamp for the next review.
+	DowngradeStep  int     `gorm:"column:downgrade_step"`              // Downgrade step for the word review.
+	TotalCorrect   int     `gorm:"column:total_correct"`               // Total number of correct answers.
+	TotalWrong     int     `gorm:"column:total_wrong"`                 // Total number of wrong answers.
+	Score          int     `gorm:"column:score"`                       // Score of the word review.
+	UserId         int64   `gorm:"column:user_id"`                     // Unique identifier for the user.
+	Easiness       float64 `gorm:"column:easiness"`                    // SM-2 easiness factor, starts at 2.5 and never drops below 1.3.
+	Interval       int     `gorm:"column:interval"`                    // SM-2 interval in days until the next review.
+	Repetitions    int     `gorm:"column:repetitions"`                 // Number of consecutive successful reviews (quality >= 3).
 }
 
 // TableName returns the name of the database table for the WordsReciteRecord model.
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+// Package srs implements the SuperMemo SM-2 spaced-repetition algorithm on
+// top of model.WordsReciteRecord, replacing ad-hoc fixed level bumps with a
+// schedule derived from how well the user recalled a word.
+package srs
+
+import (
+	"math"
+	"time"
+
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/model"
+)
+
+const (
+	// DefaultEasiness is the starting easiness factor for a word that has
+	// never been reviewed.
+	DefaultEasiness = 2.5
+	// MinEasiness is the floor the easiness factor can never drop below.
+	MinEasiness = 1.3
+	// MaxLevel caps WordsReciteRecord.Level so GetCompletedWordsCountFromRecord
+	// keeps working against a bounded range.
+	MaxLevel = 8
+	// secondsPerDay converts an SM-2 interval (days) into a Unix timestamp offset.
+	secondsPerDay = 86400
+)
+
+// Schedule applies one SM-2 review step to rec for the given recall quality
+// (0-5, where 3-5 count as a successful recall) and returns the updated
+// record.
+func Schedule(rec *model.WordsReciteRecord, quality int) *model.WordsReciteRecord {
+	now := time.Now().Unix()
+	if rec.Easiness == 0 {
+		rec.Easiness = DefaultEasiness
+	}
+
+	if quality < 3 {
+		rec.Repetitions = 0
+		rec.Interval = 1
+	} else {
+		switch rec.Repetitions {
+		case 0:
+			rec.Interval = 1
+		case 1:
+			rec.Interval = 6
+		default:
+			rec.Interval = int(math.Round(float64(rec.Interval) * rec.Easiness))
+		}
+		rec.Repetitions++
+	}
+
+	rec.Easiness = math.Max(MinEasiness, rec.Easiness+0.1-float64(5-quality)*(0.08+float64(5-quality)*0.02))
+
+	rec.NextReviewTime = now + int64(rec.Interval)*secondsPerDay
+	// Level must stay monotonic even though Repetitions resets to 0 on a
+	// missed review, so it only ever advances toward MaxLevel and never
+	// falls back with it.
+	rec.Level = min(max(rec.Level, rec.Repetitions), MaxLevel)
+
+	if quality >= 3 {
+		rec.TotalCorrect++
+	} else {
+		rec.TotalWrong++
+	}
+
+	return rec
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
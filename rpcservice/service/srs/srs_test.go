@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Bytedance Ltd. and/or its affiliates
+// SPDX-License-Identifier: MIT
+
+package srs
+
+import (
+	"testing"
+
+	"github.com/Trae-AI/stream-to-river/rpcservice/dal/model"
+)
+
+func TestSchedule_Recurrence(t *testing.T) {
+	tests := []struct {
+		name            string
+		rec             model.WordsReciteRecord
+		quality         int
+		wantInterval    int
+		wantRepetitions int
+		wantLevel       int
+	}{
+		{
+			name:            "first successful review",
+			rec:             model.WordsReciteRecord{Repetitions: 0, Interval: 0, Easiness: DefaultEasiness},
+			quality:         5,
+			wantInterval:    1,
+			wantRepetitions: 1,
+			wantLevel:       1,
+		},
+		{
+			name:            "second successful review",
+			rec:             model.WordsReciteRecord{Repetitions: 1, Interval: 1, Easiness: DefaultEasiness},
+			quality:         4,
+			wantInterval:    6,
+			wantRepetitions: 2,
+			wantLevel:       2,
+		},
+		{
+			name:            "third successful review multiplies by easiness",
+			rec:             model.WordsReciteRecord{Repetitions: 2, Interval: 6, Easiness: DefaultEasiness},
+			quality:         5,
+			wantInterval:    15, // round(6 * 2.5)
+			wantRepetitions: 3,
+			wantLevel:       3,
+		},
+		{
+			name:            "quality below 3 resets repetitions and interval but not level",
+			rec:             model.WordsReciteRecord{Level: 4, Repetitions: 4, Interval: 15, Easiness: DefaultEasiness},
+			quality:         2,
+			wantInterval:    1,
+			wantRepetitions: 0,
+			wantLevel:       4,
+		},
+		{
+			name:            "level caps at MaxLevel past it",
+			rec:             model.WordsReciteRecord{Level: MaxLevel, Repetitions: MaxLevel, Interval: 30, Easiness: DefaultEasiness},
+			quality:         5,
+			wantInterval:    int(75), // round(30 * 2.5)
+			wantRepetitions: MaxLevel + 1,
+			wantLevel:       MaxLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := tt.rec
+			got := Schedule(&rec, tt.quality)
+
+			if got.Interval != tt.wantInterval {
+				t.Errorf("Interval = %d, want %d", got.Interval, tt.wantInterval)
+			}
+			if got.Repetitions != tt.wantRepetitions {
+				t.Errorf("Repetitions = %d, want %d", got.Repetitions, tt.wantRepetitions)
+			}
+			if got.Level != tt.wantLevel {
+				t.Errorf("Level = %d, want %d", got.Level, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestSchedule_EasinessFloor(t *testing.T) {
+	rec := &model.WordsReciteRecord{Easiness: MinEasiness}
+
+	for i := 0; i < 5; i++ {
+		Schedule(rec, 0)
+	}
+
+	if rec.Easiness != MinEasiness {
+		t.Errorf("Easiness = %v, want it to stay at the floor %v", rec.Easiness, MinEasiness)
+	}
+}
+
+func TestSchedule_DefaultsEasinessWhenUnset(t *testing.T) {
+	rec := &model.WordsReciteRecord{}
+
+	Schedule(rec, 4)
+
+	if rec.Easiness < MinEasiness {
+		t.Errorf("Easiness = %v, want it seeded from DefaultEasiness and never below %v", rec.Easiness, MinEasiness)
+	}
+}
+
+// TestSchedule_LevelNeverDecreases guards the "monotonic counter" contract:
+// a single missed review resets Repetitions, but a word that already
+// reached a given Level must not fall back below it, or
+// GetCompletedWordsCountFromRecord's count of completed words could shrink.
+func TestSchedule_LevelNeverDecreases(t *testing.T) {
+	rec := &model.WordsReciteRecord{Easiness: DefaultEasiness}
+
+	for i := 0; i < MaxLevel; i++ {
+		Schedule(rec, 5)
+	}
+	if rec.Level != MaxLevel {
+		t.Fatalf("Level = %d after %d successful reviews, want %d", rec.Level, MaxLevel, MaxLevel)
+	}
+
+	Schedule(rec, 0)
+
+	if rec.Repetitions != 0 {
+		t.Fatalf("Repetitions = %d after a missed review, want 0", rec.Repetitions)
+	}
+	if rec.Level != MaxLevel {
+		t.Errorf("Level = %d after a missed review, want it to stay at %d", rec.Level, MaxLevel)
+	}
+}
+
+func TestSchedule_TracksCorrectAndWrongTotals(t *testing.T) {
+	rec := &model.WordsReciteRecord{Easiness: DefaultEasiness}
+
+	Schedule(rec, 5)
+	Schedule(rec, 2)
+
+	if rec.TotalCorrect != 1 {
+		t.Errorf("TotalCorrect = %d, want 1", rec.TotalCorrect)
+	}
+	if rec.TotalWrong != 1 {
+		t.Errorf("TotalWrong = %d, want 1", rec.TotalWrong)
+	}
+}